@@ -0,0 +1,187 @@
+package agentplatform
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// withBearerToken returns ctx with token attached as outbound "authorization"
+// metadata.
+func withBearerToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// TokenSource supplies access tokens to AuthInterceptor. AuthInterceptor
+// caches the returned token and calls Token again only when it has none
+// cached or a call is rejected as Unauthenticated.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// ClientOption configures NewClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	transportCreds credentials.TransportCredentials
+	agentToken     string
+	tokenSource    TokenSource
+}
+
+// WithTLS configures the control-plane connection to use TLS with the given
+// config instead of the default insecure transport.
+func WithTLS(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.transportCreds = credentials.NewTLS(config)
+	}
+}
+
+// WithAgentToken configures the client to authenticate with a durable agent
+// token, exchanging it for short-lived access tokens via the Auth RPC as
+// they expire. Mutually exclusive with WithTokenSource; the last one passed
+// to NewClient wins.
+func WithAgentToken(token string) ClientOption {
+	return func(o *clientOptions) {
+		o.agentToken = token
+		o.tokenSource = nil
+	}
+}
+
+// WithTokenSource configures the client to pull access tokens from a
+// caller-supplied TokenSource instead of performing its own Auth RPC
+// exchange. Mutually exclusive with WithAgentToken; the last one passed to
+// NewClient wins.
+func WithTokenSource(ts TokenSource) ClientOption {
+	return func(o *clientOptions) {
+		o.tokenSource = ts
+		o.agentToken = ""
+	}
+}
+
+// AuthInterceptor injects the current access token into outbound gRPC
+// metadata and transparently refreshes it on Unauthenticated responses. A
+// durable agent token is exchanged for short-lived access tokens via the
+// Auth RPC, following the split-auth-service pattern. An Unauthenticated
+// response triggers one refresh and one retry; an error from the refresh or
+// from the retried call is returned to the caller.
+type AuthInterceptor struct {
+	agentToken string
+	source     TokenSource
+
+	mu          sync.Mutex
+	agentID     string
+	accessToken string
+}
+
+// newAuthInterceptor builds an AuthInterceptor from clientOptions. It
+// returns nil if no credentials were configured, in which case the client
+// makes unauthenticated calls.
+func newAuthInterceptor(opts clientOptions) *AuthInterceptor {
+	if opts.agentToken == "" && opts.tokenSource == nil {
+		return nil
+	}
+	return &AuthInterceptor{
+		agentToken: opts.agentToken,
+		source:     opts.tokenSource,
+	}
+}
+
+// Unary returns a grpc.UnaryClientInterceptor that attaches the current
+// access token to each call and retries once after a refresh if the call
+// fails with Unauthenticated.
+func (a *AuthInterceptor) Unary() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		authedCtx, err := a.attach(ctx)
+		if err != nil {
+			return err
+		}
+		err = invoker(authedCtx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+		token, err := a.refresh(ctx)
+		if err != nil {
+			return err
+		}
+		return invoker(withBearerToken(ctx, token), method, req, reply, cc, opts...)
+	}
+}
+
+// Stream returns a grpc.StreamClientInterceptor that attaches the current
+// access token before opening a stream, refreshing once and retrying the
+// open if it is rejected as Unauthenticated.
+func (a *AuthInterceptor) Stream() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		authedCtx, err := a.attach(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stream, err := streamer(authedCtx, desc, cc, method, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return stream, err
+		}
+		token, err := a.refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(withBearerToken(ctx, token), desc, cc, method, opts...)
+	}
+}
+
+// attach adds the current access token to ctx's outbound metadata,
+// fetching one first if none has been cached yet.
+func (a *AuthInterceptor) attach(ctx context.Context) (context.Context, error) {
+	a.mu.Lock()
+	token := a.accessToken
+	a.mu.Unlock()
+	if token == "" {
+		var err error
+		token, err = a.refresh(ctx)
+		if err != nil {
+			return ctx, err
+		}
+	}
+	return withBearerToken(ctx, token), nil
+}
+
+// refresh fetches a new access token, either from the configured
+// TokenSource or by exchanging the durable agent token via the Auth RPC,
+// and caches it. It takes a plain ctx (not one already carrying a stale
+// token) so retries never accumulate more than one "authorization" value in
+// outbound metadata.
+func (a *AuthInterceptor) refresh(ctx context.Context) (string, error) {
+	var token, agentID string
+	var err error
+	if a.source != nil {
+		token, err = a.source.Token(ctx)
+	} else {
+		token, agentID, err = a.exchangeAgentToken(ctx)
+	}
+	if err != nil {
+		return "", fmt.Errorf("agentplatform: refresh access token: %w", err)
+	}
+
+	a.mu.Lock()
+	a.accessToken = token
+	if agentID != "" {
+		a.agentID = agentID
+	}
+	a.mu.Unlock()
+	return token, nil
+}
+
+// exchangeAgentToken calls the Auth RPC to exchange the durable agent token
+// for a short-lived access token and the caller's agent ID.
+//
+// Placeholder until proto generation is run: this will call
+// ControlPlaneClient.Auth.
+func (a *AuthInterceptor) exchangeAgentToken(ctx context.Context) (accessToken, agentID string, err error) {
+	return "", "", fmt.Errorf("agentplatform: token refresh requires generated protobuf code, see proto/agent_platform.proto")
+}
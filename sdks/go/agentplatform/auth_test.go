@@ -0,0 +1,103 @@
+package agentplatform
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTokenSource returns a new token on every call, tracking how many times
+// it was asked.
+type fakeTokenSource struct {
+	calls int32
+}
+
+func (f *fakeTokenSource) Token(ctx context.Context) (string, error) {
+	n := atomic.AddInt32(&f.calls, 1)
+	if n == 1 {
+		return "stale-token", nil
+	}
+	return "fresh-token", nil
+}
+
+func outgoingAuthValues(ctx context.Context) []string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return md.Get("authorization")
+}
+
+func TestAttachFetchesAndCachesToken(t *testing.T) {
+	source := &fakeTokenSource{}
+	a := &AuthInterceptor{source: source}
+
+	ctx, err := a.attach(context.Background())
+	if err != nil {
+		t.Fatalf("attach returned error: %v", err)
+	}
+	if got := outgoingAuthValues(ctx); len(got) != 1 || got[0] != "Bearer stale-token" {
+		t.Fatalf("outgoing authorization metadata = %v, want exactly [\"Bearer stale-token\"]", got)
+	}
+
+	if _, err := a.attach(context.Background()); err != nil {
+		t.Fatalf("second attach returned error: %v", err)
+	}
+	if source.calls != 1 {
+		t.Fatalf("Token called %d times, want 1 (cached accessToken should be reused)", source.calls)
+	}
+}
+
+func TestUnaryRetriesOnceWithoutDoubleStampingToken(t *testing.T) {
+	source := &fakeTokenSource{}
+	a := &AuthInterceptor{source: source}
+	unary := a.Unary()
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		tokens := outgoingAuthValues(ctx)
+		if len(tokens) != 1 {
+			t.Fatalf("call %d: outgoing authorization metadata has %d values, want exactly 1: %v", calls, len(tokens), tokens)
+		}
+		if calls == 1 {
+			if tokens[0] != "Bearer stale-token" {
+				t.Fatalf("first call token = %q, want stale-token", tokens[0])
+			}
+			return status.Error(codes.Unauthenticated, "expired")
+		}
+		if tokens[0] != "Bearer fresh-token" {
+			t.Fatalf("retry token = %q, want fresh-token", tokens[0])
+		}
+		return nil
+	}
+
+	err := unary(context.Background(), "/Method", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatalf("unary interceptor returned error after retry: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("invoker called %d times, want 2 (original + one retry)", calls)
+	}
+}
+
+// TestRefreshIsRaceFree exercises concurrent refresh() calls (the situation
+// that two in-flight RPCs both hitting a cold cache, or both getting
+// Unauthenticated at once, put AuthInterceptor in) under the race detector.
+func TestRefreshIsRaceFree(t *testing.T) {
+	a := &AuthInterceptor{source: &fakeTokenSource{}}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := a.refresh(context.Background()); err != nil {
+				t.Errorf("refresh returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,117 @@
+package agentplatform
+
+import (
+	"context"
+	"errors"
+)
+
+// ThresholdBasis selects what a ThresholdRule's Percent is measured against.
+type ThresholdBasis int
+
+const (
+	// CurrentSpend measures Percent against tokens/tool-invocations used so
+	// far against the budget's limit.
+	CurrentSpend ThresholdBasis = iota
+	// ForecastedSpend measures Percent against a projected end-of-period
+	// spend, extrapolated from current usage.
+	ForecastedSpend
+)
+
+// ThresholdRule fires a BudgetEvent when a budget crosses Percent of its
+// limit, measured per Basis. Modeled after cloud billing budget thresholds.
+type ThresholdRule struct {
+	Percent float64
+	Basis   ThresholdBasis
+}
+
+// NotificationsRule controls where BudgetEvents are delivered.
+type NotificationsRule struct {
+	// PubsubTopic, if set, receives a message for every crossed threshold.
+	PubsubTopic string
+	// MonitoringChannels are additional sinks (e.g. Slack, PagerDuty webhook
+	// IDs) configured on the control plane that also receive the event.
+	MonitoringChannels []string
+	// DisableDefaultRecipients suppresses the control plane's built-in
+	// recipients (e.g. the org's default alert email) for this budget.
+	DisableDefaultRecipients bool
+}
+
+// BudgetSpec describes a budget to create or update via CreateBudget /
+// UpdateBudget.
+type BudgetSpec struct {
+	OrgID           string
+	AgentID         string
+	TokenLimit      int64
+	ToolInvocations int32
+	Thresholds      []ThresholdRule
+	Notifications   NotificationsRule
+}
+
+// BudgetEvent is delivered to subscribers when an agent crosses a
+// threshold configured on its budget.
+type BudgetEvent struct {
+	BudgetID   string
+	AgentID    string
+	Threshold  ThresholdRule
+	Percent    float64
+	TokensUsed int64
+}
+
+// budgetEventStream is the minimal server-streaming interface
+// SubscribeBudgetEvents needs. It is satisfied by the generated
+// ControlPlane_SubscribeBudgetEventsClient once proto generation is run.
+type budgetEventStream interface {
+	Recv() (*BudgetEvent, error)
+}
+
+// CreateBudget creates a new budget from spec and returns its current state.
+func (c *Client) CreateBudget(ctx context.Context, spec BudgetSpec) (*BudgetInfo, error) {
+	return nil, errors.New("agentplatform: CreateBudget requires generated protobuf code, see proto/agent_platform.proto")
+}
+
+// UpdateBudget replaces the limits, thresholds, and notification settings of
+// an existing budget.
+func (c *Client) UpdateBudget(ctx context.Context, budgetID string, spec BudgetSpec) (*BudgetInfo, error) {
+	return nil, errors.New("agentplatform: UpdateBudget requires generated protobuf code, see proto/agent_platform.proto")
+}
+
+// ListBudgets returns all budgets configured for orgID.
+func (c *Client) ListBudgets(ctx context.Context, orgID string) ([]*BudgetInfo, error) {
+	return nil, errors.New("agentplatform: ListBudgets requires generated protobuf code, see proto/agent_platform.proto")
+}
+
+// SubscribeBudgetEvents opens a server-streaming subscription and returns a
+// channel of BudgetEvents for orgID, so operators can wire alerts into
+// external systems without polling CheckBudget in a loop. The channel is
+// closed when ctx is canceled or the stream ends.
+func (c *Client) SubscribeBudgetEvents(ctx context.Context, orgID string) (<-chan BudgetEvent, error) {
+	stream, err := c.openBudgetEventStream(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan BudgetEvent)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case events <- *event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// openBudgetEventStream opens the budget event subscription for orgID.
+//
+// Placeholder until proto generation is run: this will call
+// ControlPlaneClient.SubscribeBudgetEvents.
+func (c *Client) openBudgetEventStream(ctx context.Context, orgID string) (budgetEventStream, error) {
+	return nil, errors.New("agentplatform: SubscribeBudgetEvents requires generated protobuf code, see proto/agent_platform.proto")
+}
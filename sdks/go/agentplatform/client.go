@@ -2,7 +2,7 @@
 //
 // Usage:
 //
-//	client, err := agentplatform.NewClient("localhost:50051")
+//	client, err := agentplatform.NewClient("localhost:50051", agentplatform.WithAgentToken(token))
 //	org, err := client.CreateOrg(ctx, "my-company")
 //	agent, err := client.RegisterAgent(ctx, org.OrgID, "assistant", "executor", "")
 //	err = client.SetPolicy(ctx, org.OrgID, agent.AgentID, []string{"search"}, []string{"shell"}, 100000, 300)
@@ -10,6 +10,7 @@ package agentplatform
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"google.golang.org/grpc"
@@ -19,15 +20,36 @@ import (
 // Client is the unified Agent Platform SDK client.
 type Client struct {
 	conn *grpc.ClientConn
+	auth *AuthInterceptor
 }
 
-// NewClient connects to the Agent Platform control plane.
-func NewClient(address string) (*Client, error) {
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewClient connects to the Agent Platform control plane. By default the
+// connection is insecure and unauthenticated; pass WithTLS to use TLS and
+// WithAgentToken or WithTokenSource to attach credentials to every call.
+func NewClient(address string, opts ...ClientOption) (*Client, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	transportCreds := insecure.NewCredentials()
+	if o.transportCreds != nil {
+		transportCreds = o.transportCreds
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(transportCreds)}
+
+	auth := newAuthInterceptor(o)
+	if auth != nil {
+		dialOpts = append(dialOpts, grpc.WithUnaryInterceptor(auth.Unary()), grpc.WithStreamInterceptor(auth.Stream()))
+	}
+
+	conn, err := grpc.NewClient(address, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("connect: %w", err)
 	}
-	return &Client{conn: conn}, nil
+
+	return &Client{conn: conn, auth: auth}, nil
 }
 
 // Close closes the gRPC connection.
@@ -97,28 +119,25 @@ type UsageSummary struct {
 // See examples/ directory for usage patterns.
 
 // CreateOrg creates a new organization.
+//
+// Placeholder until proto generation is run: this will call
+// ControlPlaneClient.CreateOrg.
 func (c *Client) CreateOrg(ctx context.Context, name string) (*Org, error) {
-	// Implementation uses generated ControlPlaneClient from proto
-	// Placeholder until proto generation is run
-	return &Org{Name: name}, nil
+	return nil, errors.New("agentplatform: CreateOrg requires generated protobuf code, see proto/agent_platform.proto")
 }
 
 // RegisterAgent registers an agent under an organization.
+//
+// Placeholder until proto generation is run: this will call
+// ControlPlaneClient.RegisterAgent.
 func (c *Client) RegisterAgent(ctx context.Context, orgID, name, role, delegatedUserID string) (*Agent, error) {
-	return &Agent{
-		OrgID:           orgID,
-		Name:            name,
-		Role:            role,
-		DelegatedUserID: delegatedUserID,
-	}, nil
-}
-
-// EvaluatePolicy checks if an agent can use a specific tool.
-func (c *Client) EvaluatePolicy(ctx context.Context, orgID, agentID, toolName string, estimatedTokens int64) (*PolicyDecision, error) {
-	return &PolicyDecision{}, nil
+	return nil, errors.New("agentplatform: RegisterAgent requires generated protobuf code, see proto/agent_platform.proto")
 }
 
 // CheckBudget performs a pre-flight budget check.
+//
+// Placeholder until proto generation is run: this will call
+// ControlPlaneClient.CheckBudget.
 func (c *Client) CheckBudget(ctx context.Context, orgID, agentID string, estimatedTokens int64) (*BudgetCheck, error) {
-	return &BudgetCheck{}, nil
+	return nil, errors.New("agentplatform: CheckBudget requires generated protobuf code, see proto/agent_platform.proto")
 }
@@ -0,0 +1,35 @@
+package agentplatform
+
+import "sync"
+
+// Collector is a lightweight in-process OTLP-style span collector. Handlers
+// running inside RunAgentWorker record spans here as tool calls execute; the
+// worker drains them after each trigger and forwards them to the control
+// plane on the same stream, so an agent behind NAT doesn't need its own
+// OTLP exporter to make its traces visible.
+type Collector struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+// NewCollector creates an empty span collector.
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Record appends a completed span.
+func (col *Collector) Record(span Span) {
+	col.mu.Lock()
+	defer col.mu.Unlock()
+	col.spans = append(col.spans, span)
+}
+
+// Drain returns all spans recorded since the last call to Drain and clears
+// the collector.
+func (col *Collector) Drain() []Span {
+	col.mu.Lock()
+	defer col.mu.Unlock()
+	spans := col.spans
+	col.spans = nil
+	return spans
+}
@@ -0,0 +1,81 @@
+package agentplatform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AgentStatus is liveness metadata for a registered agent, as reported by
+// the control plane's agent-store.
+type AgentStatus struct {
+	AgentID           string
+	LastContact       time.Time
+	Version           string
+	Platform          string
+	NoSchedule        bool
+	Capabilities      []string
+	InFlightToolCalls []string
+}
+
+// AgentFilter narrows the results of ListAgents. Zero-value fields are not
+// applied as filters.
+type AgentFilter struct {
+	// Role, if set, restricts results to agents registered with this role.
+	Role string
+	// OnlineWithin, if set, restricts results to agents whose LastContact
+	// is within this duration of now.
+	OnlineWithin time.Duration
+	// NoSchedule, if non-nil, restricts results to agents with a matching
+	// maintenance flag.
+	NoSchedule *bool
+}
+
+// StartHeartbeat starts a background goroutine that pings the control plane
+// for agentID every interval, so operators can see which registered agents
+// are actually reachable. The goroutine stops when ctx is canceled.
+// StartHeartbeat returns after the first heartbeat is sent.
+func (c *Client) StartHeartbeat(ctx context.Context, agentID string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("agentplatform: StartHeartbeat interval must be positive, got %s", interval)
+	}
+
+	if err := c.sendHeartbeat(ctx, agentID); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = c.sendHeartbeat(ctx, agentID)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// sendHeartbeat pings the control plane once on behalf of agentID.
+//
+// Placeholder until proto generation is run: this will call
+// ControlPlaneClient.Heartbeat.
+func (c *Client) sendHeartbeat(ctx context.Context, agentID string) error {
+	return errors.New("agentplatform: StartHeartbeat requires generated protobuf code, see proto/agent_platform.proto")
+}
+
+// ListAgents returns liveness metadata for agents registered under orgID
+// that match filter, so ops tooling can see which agents are reachable, mark
+// them for maintenance, and reap dead ones.
+func (c *Client) ListAgents(ctx context.Context, orgID string, filter AgentFilter) ([]*AgentStatus, error) {
+	return nil, errors.New("agentplatform: ListAgents requires generated protobuf code, see proto/agent_platform.proto")
+}
+
+// GetAgentStatus returns liveness metadata for a single agent.
+func (c *Client) GetAgentStatus(ctx context.Context, agentID string) (*AgentStatus, error) {
+	return nil, errors.New("agentplatform: GetAgentStatus requires generated protobuf code, see proto/agent_platform.proto")
+}
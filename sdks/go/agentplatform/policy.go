@@ -0,0 +1,176 @@
+package agentplatform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ResourceKind identifies where in the org hierarchy a Policy is attached.
+type ResourceKind string
+
+const (
+	ResourceOrg        ResourceKind = "org"
+	ResourceSubOrg     ResourceKind = "sub_org"
+	ResourceAgentGroup ResourceKind = "agent_group"
+	ResourceAgent      ResourceKind = "agent"
+)
+
+// PolicyResource is one node in a policy hierarchy path, e.g. an org, a
+// sub-org beneath it, an agent-group beneath that, or a single agent.
+type PolicyResource struct {
+	Kind ResourceKind
+	ID   string
+}
+
+// ListValues holds the allow/deny lists for a list-constraint, e.g. the set
+// of tool names an agent may invoke.
+type ListValues struct {
+	Allowed []string
+	Denied  []string
+}
+
+// Constraint is one named, typed rule within a Policy. Exactly one of
+// AllowAll, DenyAll, InheritFromParent, or a Values/BoolValue/NumericLimit
+// field applies, matching org-policy v2 constraint semantics:
+//
+//   - list-constraint (e.g. "allowed_tools"): Values
+//   - boolean-constraint (e.g. "network_capability"): BoolValue
+//   - numeric-constraint (e.g. "token_limit", "latency_limit_ms"): NumericLimit
+type Constraint struct {
+	Name              string
+	InheritFromParent bool
+	AllowAll          bool
+	DenyAll           bool
+	Values            ListValues
+	BoolValue         *bool
+	NumericLimit      *int64
+}
+
+// Policy is a set of constraints attached to a single resource in the org
+// hierarchy.
+type Policy struct {
+	Resource    PolicyResource
+	Constraints []Constraint
+}
+
+// ConstraintDecision is the merged, resolved value of one named constraint
+// after walking the org hierarchy from root to agent. Which fields are
+// meaningful depends on the constraint's type: AllowAll, DenyAll, and Values
+// for a list-constraint (combined as AllowsTool describes), BoolValue for a
+// boolean-constraint, NumericLimit for a numeric-constraint.
+type ConstraintDecision struct {
+	Constraint   string
+	AllowAll     bool
+	DenyAll      bool
+	Values       ListValues
+	BoolValue    *bool
+	NumericLimit *int64
+	// Resource is the resource in the hierarchy whose constraint value
+	// decided the outcome (as opposed to being overridden by InheritFromParent
+	// further down), so callers can see why a tool call was blocked N levels up.
+	Resource PolicyResource
+}
+
+// AllowsTool reports whether a list-constraint decision permits toolName,
+// applying DenyAll/Values.Denied/AllowAll/Values.Allowed in that order of
+// precedence (an explicit deny always wins over an explicit allow).
+func (d ConstraintDecision) AllowsTool(toolName string) bool {
+	if d.DenyAll {
+		return false
+	}
+	for _, denied := range d.Values.Denied {
+		if denied == toolName {
+			return false
+		}
+	}
+	if d.AllowAll {
+		return true
+	}
+	for _, allowed := range d.Values.Allowed {
+		if allowed == toolName {
+			return true
+		}
+	}
+	return false
+}
+
+// WithinLimit reports whether a numeric-constraint decision permits a usage
+// of amount (e.g. estimated tokens). A decision with no NumericLimit set
+// imposes no limit.
+func (d ConstraintDecision) WithinLimit(amount int64) bool {
+	return d.NumericLimit == nil || amount <= *d.NumericLimit
+}
+
+// EffectivePolicy is the result of walking an agent's org hierarchy and
+// merging every Policy attached along the path, keyed by constraint name.
+type EffectivePolicy struct {
+	AgentID   string
+	Decisions map[string]ConstraintDecision
+}
+
+// SetOrgPolicy attaches policy to the resource at the end of path (path runs
+// root-to-leaf, e.g. []PolicyResource{{ResourceOrg, orgID}, {ResourceAgentGroup, groupID}}).
+func (c *Client) SetOrgPolicy(ctx context.Context, path []PolicyResource, policy Policy) error {
+	if len(path) == 0 {
+		return errors.New("agentplatform: SetOrgPolicy requires a non-empty resource path")
+	}
+	return errors.New("agentplatform: SetOrgPolicy requires generated protobuf code, see proto/agent_platform.proto")
+}
+
+// GetEffectivePolicy walks agentID's org hierarchy root to leaf, merging
+// each resource's Policy into a single EffectivePolicy keyed by constraint
+// name. A constraint with InheritFromParent set at a given resource takes
+// the nearest ancestor's resolved value instead of deciding the outcome
+// itself; for a list-constraint this means resolving the full Values.Allowed
+// / Values.Denied sets, not a single yes/no, so each tool name can be
+// checked against the merged lists independently.
+func (c *Client) GetEffectivePolicy(ctx context.Context, agentID string) (*EffectivePolicy, error) {
+	return nil, errors.New("agentplatform: GetEffectivePolicy requires generated protobuf code, see proto/agent_platform.proto")
+}
+
+// EvaluatePolicy checks whether agentID may invoke toolName with an
+// estimated token cost, using its effective policy. EvaluatePolicy is a
+// thin wrapper over GetEffectivePolicy: it resolves the full hierarchy and,
+// when a call is blocked, names the kind and ID of the deciding resource in
+// PolicyDecision.Reason so callers can see why. An allowed decision has an
+// empty Reason.
+func (c *Client) EvaluatePolicy(ctx context.Context, orgID, agentID, toolName string, estimatedTokens int64) (*PolicyDecision, error) {
+	effective, err := c.GetEffectivePolicy(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if decision, ok := effective.Decisions["allowed_tools"]; ok && !decision.AllowsTool(toolName) {
+		return &PolicyDecision{
+			Allowed: false,
+			Reason:  fmt.Sprintf("tool %q denied by %s policy on %s", toolName, decision.Resource.Kind, decision.Resource.ID),
+		}, nil
+	}
+
+	if decision, ok := effective.Decisions["token_limit"]; ok && !decision.WithinLimit(estimatedTokens) {
+		return &PolicyDecision{
+			Allowed: false,
+			Reason:  fmt.Sprintf("estimated %d tokens exceeds limit set by %s policy on %s", estimatedTokens, decision.Resource.Kind, decision.Resource.ID),
+		}, nil
+	}
+
+	return &PolicyDecision{Allowed: true}, nil
+}
+
+// SetPolicy is a convenience wrapper over SetOrgPolicy for the common case
+// of setting an allow/deny tool list, a token limit, and a time limit
+// directly on a single agent, without building a Policy/Constraint by hand.
+func (c *Client) SetPolicy(ctx context.Context, orgID, agentID string, allowedTools, deniedTools []string, tokenLimit int64, timeLimitSeconds int64) error {
+	return c.SetOrgPolicy(ctx, []PolicyResource{
+		{Kind: ResourceOrg, ID: orgID},
+		{Kind: ResourceAgent, ID: agentID},
+	}, Policy{
+		Resource: PolicyResource{Kind: ResourceAgent, ID: agentID},
+		Constraints: []Constraint{
+			{Name: "allowed_tools", Values: ListValues{Allowed: allowedTools, Denied: deniedTools}},
+			{Name: "token_limit", NumericLimit: &tokenLimit},
+			{Name: "time_limit_seconds", NumericLimit: &timeLimitSeconds},
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package agentplatform
+
+import "testing"
+
+func TestConstraintDecisionAllowsTool(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision ConstraintDecision
+		tool     string
+		want     bool
+	}{
+		{
+			name:     "explicit allow",
+			decision: ConstraintDecision{Values: ListValues{Allowed: []string{"search"}}},
+			tool:     "search",
+			want:     true,
+		},
+		{
+			name:     "not in allow list",
+			decision: ConstraintDecision{Values: ListValues{Allowed: []string{"search"}}},
+			tool:     "shell",
+			want:     false,
+		},
+		{
+			name:     "allow-all",
+			decision: ConstraintDecision{AllowAll: true},
+			tool:     "anything",
+			want:     true,
+		},
+		{
+			name:     "deny-all overrides allow-all",
+			decision: ConstraintDecision{AllowAll: true, DenyAll: true},
+			tool:     "anything",
+			want:     false,
+		},
+		{
+			name:     "explicit deny overrides allow-all",
+			decision: ConstraintDecision{AllowAll: true, Values: ListValues{Denied: []string{"shell"}}},
+			tool:     "shell",
+			want:     false,
+		},
+		{
+			name:     "explicit deny overrides explicit allow",
+			decision: ConstraintDecision{Values: ListValues{Allowed: []string{"shell"}, Denied: []string{"shell"}}},
+			tool:     "shell",
+			want:     false,
+		},
+		{
+			name:     "empty decision denies by default",
+			decision: ConstraintDecision{},
+			tool:     "search",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.decision.AllowsTool(tt.tool); got != tt.want {
+				t.Errorf("AllowsTool(%q) = %v, want %v", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintDecisionWithinLimit(t *testing.T) {
+	limit := int64(100)
+
+	tests := []struct {
+		name     string
+		decision ConstraintDecision
+		amount   int64
+		want     bool
+	}{
+		{"no limit set", ConstraintDecision{}, 1_000_000, true},
+		{"under limit", ConstraintDecision{NumericLimit: &limit}, 50, true},
+		{"at limit", ConstraintDecision{NumericLimit: &limit}, 100, true},
+		{"over limit", ConstraintDecision{NumericLimit: &limit}, 101, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.decision.WithinLimit(tt.amount); got != tt.want {
+				t.Errorf("WithinLimit(%d) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
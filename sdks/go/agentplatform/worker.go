@@ -0,0 +1,222 @@
+package agentplatform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// errMissingHandler is wrapped by dispatchWorkerStream when the server sends
+// a message that WorkerHandlers has no handler for.
+var errMissingHandler = errors.New("missing worker handler")
+
+// TriggerRequest is a tool invocation order pushed from the control plane to
+// a connected agent worker.
+type TriggerRequest struct {
+	TriggerID string
+	ToolName  string
+	Args      map[string]string
+	Deadline  time.Time
+}
+
+// TriggerResponse is the result of executing a TriggerRequest, streamed back
+// to the control plane on the same connection.
+type TriggerResponse struct {
+	TriggerID string
+	Output    string
+	Error     string
+}
+
+// PollRequest asks a connected agent worker to report a trace/status update
+// for an in-flight trigger.
+type PollRequest struct {
+	TriggerID string
+}
+
+// PollResponse is the worker's answer to a PollRequest.
+type PollResponse struct {
+	TriggerID string
+	Status    string
+	Spans     []Span
+	Error     string
+}
+
+// Span is a single OTLP-style trace span captured during tool execution.
+type Span struct {
+	TraceID    string
+	SpanID     string
+	ParentID   string
+	Name       string
+	StartUnix  int64
+	EndUnix    int64
+	Attributes map[string]string
+}
+
+// WorkerHandlers are the user-supplied callbacks RunAgentWorker dispatches
+// inbound server messages to. Both fields are required; RunAgentWorker
+// returns an error if the server sends a message with no matching handler.
+type WorkerHandlers struct {
+	// OnTrigger executes a tool invocation ordered by the control plane.
+	OnTrigger func(ctx context.Context, req *TriggerRequest) (*TriggerResponse, error)
+	// OnPoll reports the current status of an in-flight trigger.
+	OnPoll func(ctx context.Context, req *PollRequest) (*PollResponse, error)
+}
+
+// WorkerConfig controls reconnect/backoff behavior and span forwarding for
+// RunAgentWorker.
+type WorkerConfig struct {
+	// InitialBackoff is the delay before the first reconnect attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the reconnect delay.
+	MaxBackoff time.Duration
+	// Collector, if set, is drained after every trigger and its spans are
+	// sent back to the control plane alongside the TriggerResponse.
+	Collector *Collector
+}
+
+// DefaultWorkerConfig returns the backoff settings RunAgentWorker uses when
+// no WorkerConfig is supplied.
+func DefaultWorkerConfig() WorkerConfig {
+	return WorkerConfig{
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// mergeWorkerConfig fills any zero-value field of override with the
+// matching field from defaults, so a caller who only sets e.g. Collector
+// doesn't silently zero out the backoff settings.
+func mergeWorkerConfig(defaults, override WorkerConfig) WorkerConfig {
+	if override.InitialBackoff == 0 {
+		override.InitialBackoff = defaults.InitialBackoff
+	}
+	if override.MaxBackoff == 0 {
+		override.MaxBackoff = defaults.MaxBackoff
+	}
+	return override
+}
+
+// workerStream is the minimal bidirectional stream RunAgentWorker needs. It
+// is satisfied by the generated ControlPlane_RunAgentWorkerClient once proto
+// generation is run; it is defined here so this file only depends on the
+// narrow surface the dispatch loop needs, not the generated client directly.
+type workerStream interface {
+	Send(triggerID string, resp *TriggerResponse, spans []Span) error
+	SendPoll(resp *PollResponse) error
+	Recv() (*TriggerRequest, *PollRequest, error)
+	CloseSend() error
+}
+
+// RunAgentWorker registers agentID with the control plane and maintains a
+// persistent stream on which it receives TriggerRequest and PollRequest
+// messages, dispatching each to the matching handler in handlers and
+// streaming the response (plus any spans collected while the handler ran)
+// back on the same stream. The stream is reconnected with exponential
+// backoff if it drops. RunAgentWorker blocks until ctx is canceled or a
+// handler is missing for a message the server sent.
+func (c *Client) RunAgentWorker(ctx context.Context, agentID string, handlers WorkerHandlers, cfg ...WorkerConfig) error {
+	conf := DefaultWorkerConfig()
+	if len(cfg) > 0 {
+		conf = mergeWorkerConfig(conf, cfg[0])
+	}
+	backoff := conf.InitialBackoff
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		stream, err := c.openWorkerStream(ctx, agentID)
+		if err != nil {
+			if !sleepBackoff(ctx, &backoff, conf.MaxBackoff) {
+				return ctx.Err()
+			}
+			continue
+		}
+		backoff = conf.InitialBackoff
+
+		err = dispatchWorkerStream(ctx, stream, handlers, conf.Collector)
+		stream.CloseSend()
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, errMissingHandler) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !sleepBackoff(ctx, &backoff, conf.MaxBackoff) {
+			return ctx.Err()
+		}
+	}
+}
+
+// openWorkerStream opens the worker registration stream for agentID.
+//
+// Placeholder until proto generation is run: this will call
+// ControlPlaneClient.RunAgentWorker and adapt it to workerStream.
+func (c *Client) openWorkerStream(ctx context.Context, agentID string) (workerStream, error) {
+	return nil, errors.New("agentplatform: RunAgentWorker requires generated protobuf code, see proto/agent_platform.proto")
+}
+
+// dispatchWorkerStream reads inbound messages until the stream closes or
+// ctx is canceled, routing each to the matching handler and sending its
+// response back on the same stream.
+func dispatchWorkerStream(ctx context.Context, stream workerStream, handlers WorkerHandlers, collector *Collector) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		trigger, poll, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case trigger != nil:
+			if handlers.OnTrigger == nil {
+				return fmt.Errorf("agentplatform: received TriggerRequest but no OnTrigger handler is set: %w", errMissingHandler)
+			}
+			resp, err := handlers.OnTrigger(ctx, trigger)
+			if err != nil {
+				resp = &TriggerResponse{TriggerID: trigger.TriggerID, Error: err.Error()}
+			}
+			var spans []Span
+			if collector != nil {
+				spans = collector.Drain()
+			}
+			if err := stream.Send(trigger.TriggerID, resp, spans); err != nil {
+				return err
+			}
+		case poll != nil:
+			if handlers.OnPoll == nil {
+				return fmt.Errorf("agentplatform: received PollRequest but no OnPoll handler is set: %w", errMissingHandler)
+			}
+			resp, err := handlers.OnPoll(ctx, poll)
+			if err != nil {
+				resp = &PollResponse{TriggerID: poll.TriggerID, Error: err.Error()}
+			}
+			if err := stream.SendPoll(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sleepBackoff waits for the current backoff duration, or until ctx is
+// canceled, then doubles backoff up to max. It reports whether the wait
+// completed normally (false means ctx was canceled).
+func sleepBackoff(ctx context.Context, backoff *time.Duration, max time.Duration) bool {
+	select {
+	case <-time.After(*backoff):
+	case <-ctx.Done():
+		return false
+	}
+	if *backoff *= 2; *backoff > max {
+		*backoff = max
+	}
+	return true
+}
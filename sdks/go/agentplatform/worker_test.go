@@ -0,0 +1,156 @@
+package agentplatform
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// fakeWorkerStream replays a fixed sequence of inbound messages and records
+// everything sent back on it.
+type fakeWorkerStream struct {
+	triggers []*TriggerRequest
+	polls    []*PollRequest
+	idx      int
+
+	sentTriggerResponses []*TriggerResponse
+	sentSpans            [][]Span
+	sentPollResponses    []*PollResponse
+	closed               bool
+}
+
+func (f *fakeWorkerStream) Recv() (*TriggerRequest, *PollRequest, error) {
+	if f.idx >= len(f.triggers)+len(f.polls) {
+		return nil, nil, io.EOF
+	}
+	i := f.idx
+	f.idx++
+	if i < len(f.triggers) {
+		return f.triggers[i], nil, nil
+	}
+	return nil, f.polls[i-len(f.triggers)], nil
+}
+
+func (f *fakeWorkerStream) Send(triggerID string, resp *TriggerResponse, spans []Span) error {
+	f.sentTriggerResponses = append(f.sentTriggerResponses, resp)
+	f.sentSpans = append(f.sentSpans, spans)
+	return nil
+}
+
+func (f *fakeWorkerStream) SendPoll(resp *PollResponse) error {
+	f.sentPollResponses = append(f.sentPollResponses, resp)
+	return nil
+}
+
+func (f *fakeWorkerStream) CloseSend() error {
+	f.closed = true
+	return nil
+}
+
+func TestDispatchWorkerStreamTrigger(t *testing.T) {
+	stream := &fakeWorkerStream{
+		triggers: []*TriggerRequest{{TriggerID: "t1", ToolName: "search"}},
+	}
+	collector := NewCollector()
+	collector.Record(Span{SpanID: "s1"})
+
+	handlers := WorkerHandlers{
+		OnTrigger: func(ctx context.Context, req *TriggerRequest) (*TriggerResponse, error) {
+			return &TriggerResponse{TriggerID: req.TriggerID, Output: "ok"}, nil
+		},
+	}
+
+	if err := dispatchWorkerStream(context.Background(), stream, handlers, collector); !errors.Is(err, io.EOF) {
+		t.Fatalf("dispatchWorkerStream error = %v, want io.EOF once the stream is exhausted", err)
+	}
+
+	if len(stream.sentTriggerResponses) != 1 || stream.sentTriggerResponses[0].Output != "ok" {
+		t.Fatalf("unexpected trigger responses: %+v", stream.sentTriggerResponses)
+	}
+	if len(stream.sentSpans) != 1 || len(stream.sentSpans[0]) != 1 || stream.sentSpans[0][0].SpanID != "s1" {
+		t.Fatalf("collected spans were not forwarded with the trigger response: %+v", stream.sentSpans)
+	}
+}
+
+func TestDispatchWorkerStreamPoll(t *testing.T) {
+	stream := &fakeWorkerStream{
+		polls: []*PollRequest{{TriggerID: "t1"}},
+	}
+	handlers := WorkerHandlers{
+		OnPoll: func(ctx context.Context, req *PollRequest) (*PollResponse, error) {
+			return &PollResponse{TriggerID: req.TriggerID, Status: "running"}, nil
+		},
+	}
+
+	if err := dispatchWorkerStream(context.Background(), stream, handlers, nil); !errors.Is(err, io.EOF) {
+		t.Fatalf("dispatchWorkerStream error = %v, want io.EOF once the stream is exhausted", err)
+	}
+
+	if len(stream.sentPollResponses) != 1 || stream.sentPollResponses[0].Status != "running" {
+		t.Fatalf("poll response was not sent back on the stream: %+v", stream.sentPollResponses)
+	}
+}
+
+func TestDispatchWorkerStreamMissingHandler(t *testing.T) {
+	stream := &fakeWorkerStream{polls: []*PollRequest{{TriggerID: "t1"}}}
+
+	err := dispatchWorkerStream(context.Background(), stream, WorkerHandlers{}, nil)
+	if err == nil {
+		t.Fatal("expected an error when no OnPoll handler is set")
+	}
+}
+
+func TestMergeWorkerConfigKeepsDefaultsForUnsetFields(t *testing.T) {
+	merged := mergeWorkerConfig(DefaultWorkerConfig(), WorkerConfig{Collector: NewCollector()})
+
+	if merged.InitialBackoff != DefaultWorkerConfig().InitialBackoff {
+		t.Errorf("InitialBackoff = %s, want default %s", merged.InitialBackoff, DefaultWorkerConfig().InitialBackoff)
+	}
+	if merged.MaxBackoff != DefaultWorkerConfig().MaxBackoff {
+		t.Errorf("MaxBackoff = %s, want default %s", merged.MaxBackoff, DefaultWorkerConfig().MaxBackoff)
+	}
+	if merged.Collector == nil {
+		t.Error("Collector override was dropped by the merge")
+	}
+}
+
+func TestSleepBackoffDoublesAndCaps(t *testing.T) {
+	backoff := 10 * time.Millisecond
+	max := 35 * time.Millisecond
+
+	if !sleepBackoff(context.Background(), &backoff, max) || backoff != 20*time.Millisecond {
+		t.Fatalf("after 1st call backoff = %s, want 20ms", backoff)
+	}
+	if !sleepBackoff(context.Background(), &backoff, max) || backoff != max {
+		t.Fatalf("after 2nd call backoff = %s, want capped at %s", backoff, max)
+	}
+	if !sleepBackoff(context.Background(), &backoff, max) || backoff != max {
+		t.Fatalf("backoff should stay capped at %s, got %s", max, backoff)
+	}
+}
+
+func TestSleepBackoffReturnsFalseOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	backoff := time.Minute
+	if sleepBackoff(ctx, &backoff, time.Hour) {
+		t.Fatal("expected sleepBackoff to report false for an already-canceled context")
+	}
+}
+
+func TestRunAgentWorkerGivesUpWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	c := &Client{}
+	err := c.RunAgentWorker(ctx, "agent-1", WorkerHandlers{}, WorkerConfig{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("RunAgentWorker error = %v, want context.DeadlineExceeded", err)
+	}
+}